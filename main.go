@@ -1,30 +1,27 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/joho/godotenv"
-)
-
-type ZabbixResponse struct {
-	Jsonrpc string      `json:"jsonrpc"`
-	Result  interface{} `json:"result"`
-	Error   ZabbixError `json:"error"`
-	Id      int         `json:"id"`
-}
 
-type ZabbixError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    string `json:"data"`
-}
+	"github.com/Norisaline/golang-zabbix-config/pkg/auth"
+	"github.com/Norisaline/golang-zabbix-config/pkg/export"
+	"github.com/Norisaline/golang-zabbix-config/pkg/zabbix"
+)
 
+// Host is the XML export shape for a host. It's built from zabbix.Host by
+// toExportHost rather than mirroring the API response directly, since the
+// two have different field sets and tags.
 type Host struct {
 	XMLName      xml.Name    `xml:"host"`
 	HostID       string      `xml:"hostid"`
@@ -62,6 +59,19 @@ type Metric struct {
 	Value  string `json:"value"`
 }
 
+// Metrics is the collected-metrics payload for a single host. It implements
+// export.MetricsProvider so sinks like PrometheusRemoteWrite can consume it
+// without depending on this package.
+type Metrics []Metric
+
+func (m Metrics) Samples() []export.Sample {
+	samples := make([]export.Sample, len(m))
+	for i, metric := range m {
+		samples[i] = export.Sample{Name: metric.Name, Key: metric.Key, Value: metric.Value}
+	}
+	return samples
+}
+
 type Trigger struct {
 	TriggerID   string `json:"triggerid"`
 	Description string `json:"description"`
@@ -69,31 +79,123 @@ type Trigger struct {
 	Status      string `json:"status"`
 }
 
-// saveToXML сохраняет данные в файл XML
-func saveToXML(filename string, data interface{}) {
-	xmlData, err := xml.MarshalIndent(data, "", "  ")
-	if err != nil {
-		log.Fatalf("Ошибка при преобразовании данных в XML: %v", err)
+// toExportHost converts a typed zabbix.Host into the XML shape the
+// exporters write. Availability now comes from the first interface, since
+// Zabbix 6.0 moved "available" off the host itself.
+func toExportHost(h zabbix.Host) Host {
+	host := Host{
+		HostID:   h.HostID,
+		HostName: h.Name,
+		Status:   h.Status,
+		Notes:    h.Description,
 	}
 
-	// Добавляем заголовок XML перед сохранением
-	err = os.WriteFile(filename, []byte(xml.Header+string(xmlData)), 0644)
-	if err != nil {
-		log.Fatalf("Ошибка при сохранении XML-файла %s: %v", filename, err)
+	availability := "Unknown"
+	if len(h.Interfaces) > 0 {
+		host.IPAddress = h.Interfaces[0].IP
+		switch h.Interfaces[0].Available {
+		case "1":
+			availability = "Available"
+		case "0":
+			availability = "Unavailable"
+		}
+	}
+	host.Availability = availability
+
+	for _, group := range h.Groups {
+		host.Groups = append(host.Groups, Group{GroupID: group.GroupID, Name: group.Name})
+	}
+	for _, tmpl := range h.ParentTemplates {
+		host.Templates = append(host.Templates, Template{TemplateID: tmpl.TemplateID, Name: tmpl.Name})
+	}
+	return host
+}
+
+// buildSink assembles the Exporter used for this run from EXPORT_SINKS
+// (comma-separated: xml,json,yaml,s3,gcs,prometheus), each fanned out to by
+// a single export.Multi dispatcher. Falls back to "xml,json", which writes
+// every kind in both encodings and so includes the historical host.xml,
+// metrics.json and triggers.json files. The s3/gcs/prometheus sinks additionally
+// require their own env config (bucket, prefix, remote-write URL, ...) --
+// a sink missing its config is logged and skipped rather than failing the
+// whole run.
+func buildSink(ctx context.Context, exportDir string) export.Exporter {
+	kinds := os.Getenv("EXPORT_SINKS")
+	if kinds == "" {
+		kinds = "xml,json"
 	}
+
+	var sinks []export.Exporter
+	for _, kind := range strings.Split(kinds, ",") {
+		switch strings.TrimSpace(kind) {
+		case "xml":
+			sinks = append(sinks, export.FileXML{Dir: exportDir})
+		case "json":
+			sinks = append(sinks, export.FileJSON{Dir: exportDir})
+		case "yaml":
+			sinks = append(sinks, export.YAML{Dir: exportDir})
+		case "s3":
+			if sink := buildS3Sink(ctx); sink != nil {
+				sinks = append(sinks, sink)
+			}
+		case "gcs":
+			if sink := buildGCSSink(ctx); sink != nil {
+				sinks = append(sinks, sink)
+			}
+		case "prometheus":
+			if sink := buildPrometheusSink(); sink != nil {
+				sinks = append(sinks, sink)
+			}
+		default:
+			log.Printf("Неизвестный приёмник экспорта %q, пропускаем", kind)
+		}
+	}
+	return export.NewMulti(sinks...)
 }
 
-// saveToJSON сохраняет данные в файл JSON
-func saveToJSON(filename string, data interface{}) {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+// buildS3Sink configures the S3 sink from EXPORT_S3_BUCKET (required) and
+// EXPORT_S3_PREFIX (optional); AWS credentials come from the SDK's usual
+// env vars / shared config / IAM role.
+func buildS3Sink(ctx context.Context) export.Exporter {
+	bucket := os.Getenv("EXPORT_S3_BUCKET")
+	if bucket == "" {
+		log.Printf("EXPORT_S3_BUCKET не задан, пропускаем приёмник s3")
+		return nil
+	}
+	putter, err := export.NewS3Putter(ctx, bucket)
 	if err != nil {
-		log.Fatalf("Ошибка при преобразовании данных в JSON: %v", err)
+		log.Printf("настройка приёмника s3: %v", err)
+		return nil
 	}
+	return export.S3{Bucket: bucket, Prefix: os.Getenv("EXPORT_S3_PREFIX"), Client: putter}
+}
 
-	err = os.WriteFile(filename, jsonData, 0644)
+// buildGCSSink configures the GCS sink from EXPORT_GCS_BUCKET (required)
+// and EXPORT_GCS_PREFIX (optional); credentials come from Application
+// Default Credentials.
+func buildGCSSink(ctx context.Context) export.Exporter {
+	bucket := os.Getenv("EXPORT_GCS_BUCKET")
+	if bucket == "" {
+		log.Printf("EXPORT_GCS_BUCKET не задан, пропускаем приёмник gcs")
+		return nil
+	}
+	putter, err := export.NewGCSPutter(ctx, bucket)
 	if err != nil {
-		log.Fatalf("Ошибка при сохранении JSON-файла %s: %v", filename, err)
+		log.Printf("настройка приёмника gcs: %v", err)
+		return nil
+	}
+	return export.GCS{Bucket: bucket, Prefix: os.Getenv("EXPORT_GCS_PREFIX"), Client: putter}
+}
+
+// buildPrometheusSink configures the Prometheus remote-write sink from
+// EXPORT_PROMETHEUS_URL (required).
+func buildPrometheusSink() export.Exporter {
+	url := os.Getenv("EXPORT_PROMETHEUS_URL")
+	if url == "" {
+		log.Printf("EXPORT_PROMETHEUS_URL не задан, пропускаем приёмник prometheus")
+		return nil
 	}
+	return export.PrometheusRemoteWrite{Client: export.NewPrometheusPusher(url)}
 }
 
 func main() {
@@ -102,241 +204,109 @@ func main() {
 		log.Fatal("Ошибка загрузки .env файла")
 	}
 
-	zabbixUser := os.Getenv("ZBX_USER")
-	zabbixPassword := os.Getenv("ZBX_PASSWD")
 	zabbixServer := os.Getenv("ZBX_URL")
-	exportDir := os.Getenv("EXPORT_DIRECTORY")
 
-	client := resty.New()
-	client.SetTimeout(30 * time.Second)
-
-	authResp, err := client.R().
-		SetHeader("Content-Type", "application/json-rpc").
-		SetBody(map[string]interface{}{
-			"jsonrpc": "2.0",
-			"method":  "user.login",
-			"params": map[string]string{
-				"user":     zabbixUser,
-				"password": zabbixPassword,
-			},
-			"id": 1,
-		}).
-		Post(zabbixServer)
-
-	if err != nil {
-		log.Fatalf("Ошибка при запросе к Zabbix API: %v", err)
-	}
+	httpClient := resty.New()
+	httpClient.SetTimeout(30 * time.Second)
 
-	var authResult ZabbixResponse
-	err = json.Unmarshal(authResp.Body(), &authResult)
+	ctx := context.Background()
+	authenticator, err := auth.FromEnv(httpClient, zabbixServer)
 	if err != nil {
-		log.Fatalf("Ошибка при разборе ответа от Zabbix API: %v", err)
+		log.Fatalf("Ошибка настройки аутентификации: %v", err)
 	}
 
-	if authResult.Error.Code != 0 {
-		log.Fatalf("Ошибка аутентификации: %s", authResult.Error.Message)
+	zc := zabbix.NewClient(httpClient, zabbixServer, authenticator)
+	if err := zc.Authenticate(ctx); err != nil {
+		log.Fatalf("Ошибка аутентификации: %v", err)
 	}
 
-	zabbixToken, ok := authResult.Result.(string)
-	if !ok || zabbixToken == "" {
-		log.Fatalf("Ошибка: Токен аутентификации не получен")
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		// serve runs until interrupted, so its context needs to be
+		// cancelled on SIGINT/SIGTERM for ListenAndServe's and ServeGRPC's
+		// graceful-shutdown goroutines to ever fire.
+		serveCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := runServe(serveCtx, zc); err != nil {
+			log.Fatalf("Ошибка сервера: %v", err)
+		}
+		return
 	}
 
-	exportHostsWithDetails(client, zabbixToken, zabbixServer, exportDir)
-
-	fmt.Println("Экспорт данных завершен.")
+	runExport(ctx, zc)
 }
 
-func exportHostsWithDetails(client *resty.Client, token, server, exportDir string) {
-	resp, err := client.R().
-		SetHeader("Content-Type", "application/json-rpc").
-		SetBody(map[string]interface{}{
-			"jsonrpc": "2.0",
-			"method":  "host.get",
-			"params": map[string]interface{}{
-				"output":           "extend",
-				"selectGroups":     "extend",
-				"selectTemplates":  "extend",
-				"selectInterfaces": []string{"interfaceid", "ip", "port", "type"},
-			},
-			"auth": token,
-			"id":   1,
-		}).
-		Post(server)
-
-	if err != nil {
-		log.Fatalf("Ошибка при запросе к Zabbix API: %v", err)
-	}
+// runExport is the original one-shot behaviour: fetch every host and write
+// it, its metrics and its triggers to the configured sinks.
+func runExport(ctx context.Context, zc *zabbix.Client) {
+	exportDir := os.Getenv("EXPORT_DIRECTORY")
 
-	var result ZabbixResponse
-	err = json.Unmarshal(resp.Body(), &result)
+	hosts, err := zc.HostGet(ctx, zabbix.HostGetParams{
+		Output:           "extend",
+		SelectGroups:     "extend",
+		SelectTemplates:  "extend",
+		SelectInterfaces: []string{"interfaceid", "ip", "port", "type", "available"},
+	})
 	if err != nil {
-		log.Fatalf("Ошибка при разборе ответа от Zabbix API: %v", err)
+		log.Fatalf("Ошибка при получении списка хостов: %v", err)
 	}
-
-	if result.Result == nil || len(result.Result.([]interface{})) == 0 {
+	if len(hosts) == 0 {
 		log.Println("Нет доступных хостов для экспорта.")
 		return
 	}
 
-	for _, item := range result.Result.([]interface{}) {
-		data := item.(map[string]interface{})
-		availability := "Unknown"
-		if data["available"].(string) == "1" {
-			availability = "Available"
-		} else if data["available"].(string) == "0" {
-			availability = "Unavailable"
-		}
-
-		host := Host{
-			HostID:       data["hostid"].(string),
-			HostName:     data["name"].(string),
-			IPAddress:    data["interfaces"].([]interface{})[0].(map[string]interface{})["ip"].(string),
-			Status:       data["status"].(string),
-			Availability: availability,
-		}
-
-		if desc, ok := data["description"].(string); ok {
-			host.Notes = desc
-		}
+	sink := buildSink(ctx, exportDir)
+	results := runExportPool(ctx, zc, sink, hosts, exportWorkerCount())
+	printExportSummary(results)
 
-		if groups, ok := data["groups"].([]interface{}); ok {
-			for _, group := range groups {
-				groupData := group.(map[string]interface{})
-				host.Groups = append(host.Groups, Group{
-					GroupID: groupData["groupid"].(string),
-					Name:    groupData["name"].(string),
-				})
-			}
-		}
+	fmt.Println("Экспорт данных завершен.")
+}
 
-		if templates, ok := data["parentTemplates"].([]interface{}); ok {
-			for _, template := range templates {
-				templateData := template.(map[string]interface{})
-				host.Templates = append(host.Templates, Template{
-					TemplateID: templateData["templateid"].(string),
-					Name:       templateData["name"].(string),
-				})
-			}
+// printExportSummary logs one line per host so a run with partial failures
+// still reports which hosts succeeded and which didn't, instead of a single
+// pass/fail result for the whole export.
+func printExportSummary(results []hostResult) {
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			log.Printf("хост %s (%s): ОШИБКА: %v", r.HostName, r.HostID, r.Err)
+			continue
 		}
-
-		// Создаём директорию для хоста
-		hostDir := fmt.Sprintf("%s/hosts/%s", exportDir, host.HostName)
-		os.MkdirAll(hostDir, 0755)
-
-		// Сохраняем данные хоста в XML
-		saveToXML(fmt.Sprintf("%s/host.xml", hostDir), host)
-
-		// Экспортируем метрики и триггеры в JSON
-		exportMetricsForHost(client, token, server, hostDir, host.HostID)
-		exportTriggersForHost(client, token, server, hostDir, host.HostID)
+		fmt.Printf("хост %s (%s): экспортирован успешно\n", r.HostName, r.HostID)
 	}
-	fmt.Println("Экспорт хостов завершен.")
+	fmt.Printf("Итого: %d хостов, %d с ошибками.\n", len(results), failed)
 }
 
-func exportMetricsForHost(client *resty.Client, token, server, hostDir, hostID string) {
-	resp, err := client.R().
-		SetHeader("Content-Type", "application/json-rpc").
-		SetBody(map[string]interface{}{
-			"jsonrpc": "2.0",
-			"method":  "item.get",
-			"params": map[string]interface{}{
-				"output":  "extend",
-				"hostids": hostID,
-			},
-			"auth": token,
-			"id":   1,
-		}).
-		Post(server)
-
+func exportMetricsForHost(ctx context.Context, zc *zabbix.Client, sink export.Exporter, hostID, hostName string) error {
+	items, err := zc.ItemGet(ctx, zabbix.ItemGetParams{Output: "extend", HostIDs: hostID})
 	if err != nil {
-		log.Printf("Ошибка при получении метрик для хоста %s: %v", hostID, err)
-		return
+		return fmt.Errorf("item.get для хоста %s: %w", hostID, err)
 	}
 
-	var result ZabbixResponse
-	err = json.Unmarshal(resp.Body(), &result)
-	if err != nil {
-		log.Printf("Ошибка при разборе метрик хоста %s: %v", hostID, err)
-		return
+	metrics := make(Metrics, len(items))
+	for i, item := range items {
+		metrics[i] = Metric{ItemID: item.ItemID, Name: item.Name, Key: item.Key, Value: item.LastValue}
 	}
 
-	metrics := []Metric{}
-	for _, item := range result.Result.([]interface{}) {
-		data := item.(map[string]interface{})
-		metric := Metric{
-			ItemID: data["itemid"].(string),
-			Name:   data["name"].(string),
-			Key:    data["key_"].(string),
-			Value:  data["lastvalue"].(string),
-		}
-		metrics = append(metrics, metric)
+	if err := sink.Export(export.Payload{Kind: export.KindMetrics, HostID: hostID, HostName: hostName, Data: metrics}); err != nil {
+		return fmt.Errorf("экспорт метрик хоста %s: %w", hostID, err)
 	}
-
-	saveToJSON(fmt.Sprintf("%s/metrics.json", hostDir), metrics)
-	fmt.Printf("Метрики успешно экспортированы для хоста %s в %s/metrics.json\n", hostID, hostDir)
+	return nil
 }
 
-func exportTriggersForHost(client *resty.Client, token, server, hostDir, hostID string) {
-	resp, err := client.R().
-		SetHeader("Content-Type", "application/json-rpc").
-		SetBody(map[string]interface{}{
-			"jsonrpc": "2.0",
-			"method":  "trigger.get",
-			"params": map[string]interface{}{
-				"output":  "extend",
-				"hostids": []string{hostID}, // hostids должен быть массивом строк
-			},
-			"auth": token,
-			"id":   1,
-		}).
-		Post(server)
-
-	if err != nil {
-		log.Printf("Ошибка при запросе триггеров для хоста %s: %v", hostID, err)
-		return
-	}
-
-	// Логируем ответ для диагностики
-	fmt.Printf("Ответ от API для триггеров хоста %s: %s\n", hostID, string(resp.Body()))
-
-	var result ZabbixResponse
-	err = json.Unmarshal(resp.Body(), &result)
+func exportTriggersForHost(ctx context.Context, zc *zabbix.Client, sink export.Exporter, hostID, hostName string) error {
+	apiTriggers, err := zc.TriggerGet(ctx, zabbix.TriggerGetParams{Output: "extend", HostIDs: []string{hostID}})
 	if err != nil {
-		log.Printf("Ошибка при разборе ответа триггеров для хоста %s: %v", hostID, err)
-		return
-	}
-
-	// Проверяем, есть ли данные
-	if result.Result == nil {
-		log.Printf("Нет триггеров для хоста %s.\n", hostID)
-		return
+		return fmt.Errorf("trigger.get для хоста %s: %w", hostID, err)
 	}
 
-	// Сохраняем триггеры
-	triggers := []Trigger{}
-	for _, trigger := range result.Result.([]interface{}) {
-		data := trigger.(map[string]interface{})
-		tr := Trigger{
-			TriggerID:   getStringFromMap(data, "triggerid"),
-			Description: getStringFromMap(data, "description"),
-			Priority:    getStringFromMap(data, "priority"),
-			Status:      getStringFromMap(data, "status"),
-		}
-		triggers = append(triggers, tr)
+	triggers := make([]Trigger, len(apiTriggers))
+	for i, t := range apiTriggers {
+		triggers[i] = Trigger{TriggerID: t.TriggerID, Description: t.Description, Priority: t.Priority, Status: t.Status}
 	}
 
-	// Сохраняем триггеры в JSON-файл
-	saveToJSON(fmt.Sprintf("%s/triggers.json", hostDir), triggers)
-	fmt.Printf("Триггеры успешно экспортированы для хоста %s в %s/triggers.json\n", hostID, hostDir)
-}
-
-// Вспомогательная функция для безопасного извлечения строковых данных из карты
-func getStringFromMap(data map[string]interface{}, key string) string {
-	if val, ok := data[key]; ok {
-		if strVal, ok := val.(string); ok {
-			return strVal
-		}
+	if err := sink.Export(export.Payload{Kind: export.KindTriggers, HostID: hostID, HostName: hostName, Data: triggers}); err != nil {
+		return fmt.Errorf("экспорт триггеров хоста %s: %w", hostID, err)
 	}
-	return ""
+	return nil
 }
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// APITokenAuth is a static, pre-generated Zabbix API token (5.4+). It's
+// sent the same way a legacy session token is -- as the "auth" field of
+// every request body -- which is what servers before 7.0 expect; use
+// BearerAuth instead against a 7.0+ server.
+type APITokenAuth struct {
+	Token string
+}
+
+// NewAPITokenAuth returns an APITokenAuth backend loading its token from
+// ZBX_API_TOKEN, or from the file named by ZBX_API_TOKEN_FILE if that's set
+// instead.
+func NewAPITokenAuth() (*APITokenAuth, error) {
+	if token := os.Getenv("ZBX_API_TOKEN"); token != "" {
+		return &APITokenAuth{Token: token}, nil
+	}
+	if path := os.Getenv("ZBX_API_TOKEN_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("auth: APITokenAuth: чтение %s: %w", path, err)
+		}
+		return &APITokenAuth{Token: strings.TrimSpace(string(data))}, nil
+	}
+	return nil, fmt.Errorf("auth: APITokenAuth: не задан ни ZBX_API_TOKEN, ни ZBX_API_TOKEN_FILE")
+}
+
+func (a *APITokenAuth) Authenticate(ctx context.Context) (Credential, error) {
+	if a.Token == "" {
+		return nil, fmt.Errorf("auth: APITokenAuth: токен не задан")
+	}
+	return bodyCredential{token: a.Token}, nil
+}
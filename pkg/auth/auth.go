@@ -0,0 +1,57 @@
+// Package auth provides the credential backends a zabbix.Client
+// authenticates with: legacy username/password sessions, static API
+// tokens, and Zabbix 7.0's header-based Bearer auth.
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Credential attaches an established session to an outgoing Zabbix
+// JSON-RPC request, either by mutating the request body (legacy session
+// auth, API tokens on older servers) or by setting a header (Bearer auth,
+// Zabbix 7.0+).
+type Credential interface {
+	Apply(req *resty.Request, body map[string]interface{})
+}
+
+// Authenticator establishes a Credential for a Zabbix server.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (Credential, error)
+}
+
+// Refresher is implemented by Authenticators that can obtain a new
+// Credential after the server reports the current session as terminated,
+// without restarting the whole process.
+type Refresher interface {
+	Refresh(ctx context.Context) (Credential, error)
+}
+
+// sessionTerminated is implemented by errors that can say whether they
+// represent Zabbix's "Session terminated, re-login, please" response
+// (-32602), without this package needing to import pkg/zabbix's error type.
+type sessionTerminated interface {
+	SessionTerminated() bool
+}
+
+// IsSessionTerminated reports whether err (or anything it wraps) represents
+// an expired/terminated Zabbix session that warrants calling Refresh.
+func IsSessionTerminated(err error) bool {
+	var st sessionTerminated
+	return errors.As(err, &st) && st.SessionTerminated()
+}
+
+type bodyCredential struct{ token string }
+
+func (c bodyCredential) Apply(_ *resty.Request, body map[string]interface{}) {
+	body["auth"] = c.token
+}
+
+type headerCredential struct{ token string }
+
+func (c headerCredential) Apply(req *resty.Request, _ map[string]interface{}) {
+	req.SetHeader("Authorization", "Bearer "+c.token)
+}
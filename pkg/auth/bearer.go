@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// BearerAuth sends its token via an "Authorization: Bearer <token>" header
+// instead of the request body, matching Zabbix 6.4+/7.0 where auth moved
+// out of the JSON-RPC body.
+type BearerAuth struct {
+	Token string
+}
+
+// NewBearerAuth returns a BearerAuth backend loading its token from
+// ZBX_API_TOKEN (API tokens are the mechanism Zabbix expects behind Bearer
+// auth too).
+func NewBearerAuth() (*BearerAuth, error) {
+	apiToken, err := NewAPITokenAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &BearerAuth{Token: apiToken.Token}, nil
+}
+
+func (a *BearerAuth) Authenticate(ctx context.Context) (Credential, error) {
+	if a.Token == "" {
+		return nil, fmt.Errorf("auth: BearerAuth: токен не задан")
+	}
+	return headerCredential{token: a.Token}, nil
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// FromEnv selects an Authenticator backend based on ZBX_AUTH_MODE
+// ("login", the default; "token"; or "bearer"), wiring it up from the
+// corresponding ZBX_* environment variables.
+func FromEnv(http *resty.Client, server string) (Authenticator, error) {
+	switch mode := os.Getenv("ZBX_AUTH_MODE"); mode {
+	case "", "login":
+		return &UserLoginAuth{
+			HTTP:     http,
+			Server:   server,
+			User:     os.Getenv("ZBX_USER"),
+			Password: os.Getenv("ZBX_PASSWD"),
+		}, nil
+	case "token":
+		return NewAPITokenAuth()
+	case "bearer":
+		return NewBearerAuth()
+	default:
+		return nil, fmt.Errorf("auth: неизвестный ZBX_AUTH_MODE %q", mode)
+	}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// UserLoginAuth is the legacy body-auth backend: it calls user.login with a
+// username/password and threads the resulting session token through the
+// "auth" field of every subsequent request body.
+type UserLoginAuth struct {
+	HTTP     *resty.Client
+	Server   string
+	User     string
+	Password string
+}
+
+func (a *UserLoginAuth) Authenticate(ctx context.Context) (Credential, error) {
+	token, err := a.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bodyCredential{token: token}, nil
+}
+
+// Refresh re-runs user.login: the legacy API has no separate refresh call,
+// so logging in again is the refresh.
+func (a *UserLoginAuth) Refresh(ctx context.Context) (Credential, error) {
+	return a.Authenticate(ctx)
+}
+
+type loginError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *loginError) Error() string {
+	return fmt.Sprintf("auth: user.login: %s (code %d): %s", e.Message, e.Code, e.Data)
+}
+
+func (a *UserLoginAuth) login(ctx context.Context) (string, error) {
+	resp, err := a.HTTP.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json-rpc").
+		SetBody(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "user.login",
+			"params": map[string]string{
+				"user":     a.User,
+				"password": a.Password,
+			},
+			"id": 1,
+		}).
+		Post(a.Server)
+	if err != nil {
+		return "", fmt.Errorf("auth: user.login: %w", err)
+	}
+
+	var result struct {
+		Result string      `json:"result"`
+		Error  *loginError `json:"error"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return "", fmt.Errorf("auth: user.login: разбор ответа: %w", err)
+	}
+	if result.Error != nil && result.Error.Code != 0 {
+		return "", result.Error
+	}
+	if result.Result == "" {
+		return "", fmt.Errorf("auth: user.login: токен не получен")
+	}
+	return result.Result, nil
+}
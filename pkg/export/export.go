@@ -0,0 +1,69 @@
+// Package export defines the Exporter interface used to deliver collected
+// Zabbix inventory to one or more output sinks (local files, object
+// storage, a TSDB, ...) without the collection code caring which sinks are
+// active.
+package export
+
+import "fmt"
+
+// Kind identifies which part of the inventory a Payload carries. Sinks use
+// it to decide how to name/route the data they receive.
+type Kind string
+
+const (
+	KindHost     Kind = "host"
+	KindMetrics  Kind = "metrics"
+	KindTriggers Kind = "triggers"
+)
+
+// Payload is the typed unit of data handed to an Exporter. Data holds the
+// concrete value (main.Host, main.Metrics, []main.Trigger, ...); sinks
+// that care about the shape type-assert it themselves.
+type Payload struct {
+	Kind     Kind
+	HostID   string
+	HostName string
+	Data     interface{}
+}
+
+// Exporter delivers a Payload to a sink. Implementations must be safe for
+// concurrent use, since the collector may call Export for several hosts at
+// once.
+type Exporter interface {
+	Export(payload Payload) error
+}
+
+// Multi fans a Payload out to every Sink, running them all and collecting
+// every error rather than stopping at the first failure.
+type Multi struct {
+	Sinks []Exporter
+}
+
+// NewMulti returns a dispatcher that writes every Payload to all of sinks.
+func NewMulti(sinks ...Exporter) *Multi {
+	return &Multi{Sinks: sinks}
+}
+
+func (m *Multi) Export(payload Payload) error {
+	var errs []error
+	for _, sink := range m.Sinks {
+		if err := sink.Export(payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("export: %d/%d sinks failed: %w", len(errs), len(m.Sinks), joinErrors(errs))
+}
+
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	msg := errs[0].Error()
+	for _, err := range errs[1:] {
+		msg += "; " + err.Error()
+	}
+	return fmt.Errorf("%s", msg)
+}
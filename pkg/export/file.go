@@ -0,0 +1,77 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// filenames maps a payload Kind to the filename used by the file-based
+// sinks, one per supported encoding.
+var filenames = map[Kind]string{
+	KindHost:     "host",
+	KindMetrics:  "metrics",
+	KindTriggers: "triggers",
+}
+
+func hostDir(baseDir, hostName string) string {
+	return filepath.Join(baseDir, "hosts", hostName)
+}
+
+// FileXML writes each payload to <Dir>/hosts/<host>/<kind>.xml.
+type FileXML struct {
+	Dir string
+}
+
+func (f FileXML) Export(payload Payload) error {
+	name, ok := filenames[payload.Kind]
+	if !ok {
+		return fmt.Errorf("export: FileXML: unsupported kind %q", payload.Kind)
+	}
+
+	dir := hostDir(f.Dir, payload.HostName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("export: FileXML: создание директории %s: %w", dir, err)
+	}
+
+	xmlData, err := xml.MarshalIndent(payload.Data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("export: FileXML: преобразование данных в XML: %w", err)
+	}
+
+	path := filepath.Join(dir, name+".xml")
+	if err := os.WriteFile(path, []byte(xml.Header+string(xmlData)), 0644); err != nil {
+		return fmt.Errorf("export: FileXML: сохранение файла %s: %w", path, err)
+	}
+	return nil
+}
+
+// FileJSON writes each payload to <Dir>/hosts/<host>/<kind>.json.
+type FileJSON struct {
+	Dir string
+}
+
+func (f FileJSON) Export(payload Payload) error {
+	name, ok := filenames[payload.Kind]
+	if !ok {
+		return fmt.Errorf("export: FileJSON: unsupported kind %q", payload.Kind)
+	}
+
+	dir := hostDir(f.Dir, payload.HostName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("export: FileJSON: создание директории %s: %w", dir, err)
+	}
+
+	jsonData, err := json.MarshalIndent(payload.Data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("export: FileJSON: преобразование данных в JSON: %w", err)
+	}
+
+	path := filepath.Join(dir, name+".json")
+	if err := os.WriteFile(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("export: FileJSON: сохранение файла %s: %w", path, err)
+	}
+	return nil
+}
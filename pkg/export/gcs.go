@@ -0,0 +1,34 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+)
+
+// gcsPutter adapts *storage.Client to Putter, the only operation the GCS
+// sink needs.
+type gcsPutter struct {
+	client *storage.Client
+	bucket string
+}
+
+// NewGCSPutter builds a storage.Client using Application Default
+// Credentials and returns a Putter backed by bucket.
+func NewGCSPutter(ctx context.Context, bucket string) (Putter, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("export: GCS: создание клиента: %w", err)
+	}
+	return gcsPutter{client: client, bucket: bucket}, nil
+}
+
+func (p gcsPutter) Put(ctx context.Context, key string, body []byte) error {
+	w := p.client.Bucket(p.bucket).Object(key).NewWriter(ctx)
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return fmt.Errorf("export: GCS: запись gs://%s/%s: %w", p.bucket, key, err)
+	}
+	return w.Close()
+}
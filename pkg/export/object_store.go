@@ -0,0 +1,62 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Putter is the minimal object-store operation the S3 and GCS sinks need.
+// Satisfied by the thin wrappers around *s3.Client / *storage.Client in
+// s3.go and gcs.go, so the S3 and GCS sinks themselves don't use either SDK
+// directly.
+type Putter interface {
+	Put(ctx context.Context, key string, body []byte) error
+}
+
+func objectKey(prefix, hostName string, kind Kind) string {
+	name := filenames[kind]
+	if prefix == "" {
+		return fmt.Sprintf("hosts/%s/%s.ndjson", hostName, name)
+	}
+	return fmt.Sprintf("%s/hosts/%s/%s.ndjson", prefix, hostName, name)
+}
+
+// S3 pushes NDJSON payloads to an S3-compatible bucket via Putter.
+type S3 struct {
+	Bucket string
+	Prefix string
+	Client Putter
+}
+
+func (s S3) Export(payload Payload) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(payload.Data); err != nil {
+		return fmt.Errorf("export: S3: marshal payload for host %s: %w", payload.HostName, err)
+	}
+	key := objectKey(s.Prefix, payload.HostName, payload.Kind)
+	if err := s.Client.Put(context.Background(), key, buf.Bytes()); err != nil {
+		return fmt.Errorf("export: S3: put s3://%s/%s: %w", s.Bucket, key, err)
+	}
+	return nil
+}
+
+// GCS pushes NDJSON payloads to a Google Cloud Storage bucket via Putter.
+type GCS struct {
+	Bucket string
+	Prefix string
+	Client Putter
+}
+
+func (g GCS) Export(payload Payload) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(payload.Data); err != nil {
+		return fmt.Errorf("export: GCS: marshal payload for host %s: %w", payload.HostName, err)
+	}
+	key := objectKey(g.Prefix, payload.HostName, payload.Kind)
+	if err := g.Client.Put(context.Background(), key, buf.Bytes()); err != nil {
+		return fmt.Errorf("export: GCS: put gs://%s/%s: %w", g.Bucket, key, err)
+	}
+	return nil
+}
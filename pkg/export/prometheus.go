@@ -0,0 +1,44 @@
+package export
+
+import "context"
+
+// Sample is one metric value handed to a metrics sink, decoupled from the
+// caller's own Metric type.
+type Sample struct {
+	Name  string
+	Key   string
+	Value string
+}
+
+// MetricsProvider is implemented by a Payload's Data when it carries
+// metrics, so metrics-only sinks don't need to know the caller's Metric
+// type.
+type MetricsProvider interface {
+	Samples() []Sample
+}
+
+// Pusher streams samples to a remote-write endpoint (e.g. a Prometheus
+// TSDB). Satisfied by the thin wrapper around the real remote-write client
+// in prometheus_pusher.go, so PrometheusRemoteWrite itself doesn't use that
+// client directly.
+type Pusher interface {
+	Push(ctx context.Context, samples []Sample) error
+}
+
+// PrometheusRemoteWrite streams host metrics to a TSDB via Pusher. It
+// ignores payloads that aren't metrics, since triggers/host inventory has
+// no remote-write representation.
+type PrometheusRemoteWrite struct {
+	Client Pusher
+}
+
+func (p PrometheusRemoteWrite) Export(payload Payload) error {
+	if payload.Kind != KindMetrics {
+		return nil
+	}
+	provider, ok := payload.Data.(MetricsProvider)
+	if !ok {
+		return nil
+	}
+	return p.Client.Push(context.Background(), provider.Samples())
+}
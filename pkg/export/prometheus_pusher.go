@@ -0,0 +1,47 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/castai/promwrite"
+)
+
+// promPusher adapts promwrite.Client to Pusher.
+type promPusher struct {
+	client *promwrite.Client
+}
+
+// NewPrometheusPusher returns a Pusher that remote-writes to url.
+func NewPrometheusPusher(url string) Pusher {
+	return promPusher{client: promwrite.NewClient(url)}
+}
+
+// Push converts samples to a remote-write request, skipping any sample
+// whose Value isn't numeric (Zabbix item values can be strings or logs,
+// which have no Prometheus representation).
+func (p promPusher) Push(ctx context.Context, samples []Sample) error {
+	series := make([]promwrite.TimeSeries, 0, len(samples))
+	for _, s := range samples {
+		value, err := strconv.ParseFloat(s.Value, 64)
+		if err != nil {
+			continue
+		}
+		series = append(series, promwrite.TimeSeries{
+			Labels: []promwrite.Label{
+				{Name: "__name__", Value: s.Key},
+				{Name: "item", Value: s.Name},
+			},
+			Sample: promwrite.Sample{Time: time.Now(), Value: value},
+		})
+	}
+	if len(series) == 0 {
+		return nil
+	}
+	if _, err := p.client.Write(ctx, &promwrite.WriteRequest{TimeSeries: series}); err != nil {
+		return fmt.Errorf("export: Prometheus: remote-write: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Putter adapts *s3.Client to Putter, the only operation the S3 sink
+// needs.
+type s3Putter struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Putter loads AWS credentials the default SDK way (env vars, shared
+// config file, IAM role, ...) and returns a Putter backed by bucket.
+func NewS3Putter(ctx context.Context, bucket string) (Putter, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("export: S3: загрузка конфигурации AWS: %w", err)
+	}
+	return s3Putter{client: s3.NewFromConfig(cfg), bucket: bucket}, nil
+}
+
+func (p s3Putter) Put(ctx context.Context, key string, body []byte) error {
+	_, err := p.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	})
+	return err
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML writes each payload to <Dir>/hosts/<host>/<kind>.yaml.
+type YAML struct {
+	Dir string
+}
+
+func (y YAML) Export(payload Payload) error {
+	name, ok := filenames[payload.Kind]
+	if !ok {
+		return fmt.Errorf("export: YAML: unsupported kind %q", payload.Kind)
+	}
+
+	dir := hostDir(y.Dir, payload.HostName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("export: YAML: создание директории %s: %w", dir, err)
+	}
+
+	yamlData, err := yaml.Marshal(payload.Data)
+	if err != nil {
+		return fmt.Errorf("export: YAML: преобразование данных в YAML: %w", err)
+	}
+
+	path := dir + "/" + name + ".yaml"
+	if err := os.WriteFile(path, yamlData, 0644); err != nil {
+		return fmt.Errorf("export: YAML: сохранение файла %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Package inventory provides a filepath.Walk-style traversal over the
+// Zabbix inventory tree: HostGroup -> Host -> (Template, Interface, Item,
+// Trigger, Graph, LLD rule). It lets callers write filters like "only
+// hosts in group X whose template matches Y" without re-implementing
+// pagination and the cross-entity joins the Zabbix API requires.
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Norisaline/golang-zabbix-config/pkg/zabbix"
+)
+
+// Kind identifies the inventory node type a WalkEntry carries.
+type Kind string
+
+const (
+	KindHostGroup Kind = "hostgroup"
+	KindHost      Kind = "host"
+	KindTemplate  Kind = "template"
+	KindInterface Kind = "interface"
+	KindItem      Kind = "item"
+	KindTrigger   Kind = "trigger"
+	KindGraph     Kind = "graph"
+	KindLLDRule   Kind = "lldrule"
+)
+
+// WalkEntry is the node a WalkFunc is invoked with. Parent is a slash-
+// separated path to the entry's ancestor (e.g. "group/Linux servers/host/
+// web01"), and Data holds the typed zabbix.* value for Kind.
+type WalkEntry struct {
+	Kind   Kind
+	Parent string
+	Data   interface{}
+}
+
+// WalkFunc is called once per inventory node visited by Walk.
+type WalkFunc func(entry WalkEntry) error
+
+// SkipSubtree, returned by a WalkFunc, prunes traversal below the entry
+// just visited: returning it for a host group skips every host inside it,
+// and for a host skips its templates, interfaces, items, triggers, graphs
+// and LLD rules.
+var SkipSubtree = errors.New("inventory: skip subtree")
+
+// WalkRoot scopes a Walk to a subset of host groups and/or hosts.
+type WalkRoot struct {
+	// GroupIDs restricts the walk to these host groups; empty means all.
+	GroupIDs []string
+	// HostFilter, if set, is consulted for every host in scope; hosts it
+	// rejects (and their children) are skipped entirely.
+	HostFilter func(zabbix.Host) bool
+}
+
+// Walk traverses the inventory rooted at root, calling fn at each node. The
+// per-entity-type calls (item.get, trigger.get, graph.get,
+// discoveryrule.get) are each issued once per host group covering every
+// host in it, rather than once per host, so a full walk costs O(host
+// groups) round-trips rather than O(hosts).
+func Walk(ctx context.Context, zc *zabbix.Client, root WalkRoot, fn WalkFunc) error {
+	groups, err := zc.HostGroupGet(ctx, zabbix.HostGroupGetParams{Output: "extend", GroupIDs: root.GroupIDs})
+	if err != nil {
+		return fmt.Errorf("inventory: hostgroup.get: %w", err)
+	}
+
+	for _, group := range groups {
+		if err := fn(WalkEntry{Kind: KindHostGroup, Data: group}); err != nil {
+			if errors.Is(err, SkipSubtree) {
+				continue
+			}
+			return err
+		}
+		if err := walkGroup(ctx, zc, root, group, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkGroup(ctx context.Context, zc *zabbix.Client, root WalkRoot, group zabbix.HostGroup, fn WalkFunc) error {
+	hosts, err := zc.HostGet(ctx, zabbix.HostGetParams{
+		Output:           "extend",
+		GroupIDs:         []string{group.GroupID},
+		SelectGroups:     "extend",
+		SelectTemplates:  "extend",
+		SelectInterfaces: "extend",
+	})
+	if err != nil {
+		return fmt.Errorf("inventory: host.get для группы %s: %w", group.Name, err)
+	}
+	if root.HostFilter != nil {
+		filtered := hosts[:0]
+		for _, h := range hosts {
+			if root.HostFilter(h) {
+				filtered = append(filtered, h)
+			}
+		}
+		hosts = filtered
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	hostIDs := make([]string, len(hosts))
+	byHostID := make(map[string]zabbix.Host, len(hosts))
+	for i, h := range hosts {
+		hostIDs[i] = h.HostID
+		byHostID[h.HostID] = h
+	}
+
+	itemsByHost, err := itemsByHostID(ctx, zc, hostIDs)
+	if err != nil {
+		return fmt.Errorf("inventory: группа %s: %w", group.Name, err)
+	}
+	triggersByHost, err := triggersByHostID(ctx, zc, hostIDs)
+	if err != nil {
+		return fmt.Errorf("inventory: группа %s: %w", group.Name, err)
+	}
+	graphsByHost, err := graphsByHostID(ctx, zc, hostIDs)
+	if err != nil {
+		return fmt.Errorf("inventory: группа %s: %w", group.Name, err)
+	}
+	lldByHost, err := lldRulesByHostID(ctx, zc, hostIDs)
+	if err != nil {
+		return fmt.Errorf("inventory: группа %s: %w", group.Name, err)
+	}
+
+	groupPath := "group/" + group.Name
+	for _, hostID := range hostIDs {
+		host := byHostID[hostID]
+		hostPath := groupPath + "/host/" + host.Name
+
+		if err := fn(WalkEntry{Kind: KindHost, Parent: groupPath, Data: host}); err != nil {
+			if errors.Is(err, SkipSubtree) {
+				continue
+			}
+			return err
+		}
+
+		if err := walkHostChildren(hostPath, host, itemsByHost[hostID], triggersByHost[hostID], graphsByHost[hostID], lldByHost[hostID], fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkHostChildren(hostPath string, host zabbix.Host, items []zabbix.Item, triggers []zabbix.Trigger, graphs []zabbix.Graph, lldRules []zabbix.LLDRule, fn WalkFunc) error {
+	for _, tmpl := range host.ParentTemplates {
+		if err := visitChild(fn, WalkEntry{Kind: KindTemplate, Parent: hostPath, Data: tmpl}); err != nil {
+			return err
+		}
+	}
+	for _, iface := range host.Interfaces {
+		if err := visitChild(fn, WalkEntry{Kind: KindInterface, Parent: hostPath, Data: iface}); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if err := visitChild(fn, WalkEntry{Kind: KindItem, Parent: hostPath, Data: item}); err != nil {
+			return err
+		}
+	}
+	for _, trigger := range triggers {
+		if err := visitChild(fn, WalkEntry{Kind: KindTrigger, Parent: hostPath, Data: trigger}); err != nil {
+			return err
+		}
+	}
+	for _, graph := range graphs {
+		if err := visitChild(fn, WalkEntry{Kind: KindGraph, Parent: hostPath, Data: graph}); err != nil {
+			return err
+		}
+	}
+	for _, rule := range lldRules {
+		if err := visitChild(fn, WalkEntry{Kind: KindLLDRule, Parent: hostPath, Data: rule}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// visitChild calls fn, treating SkipSubtree as "this leaf has no subtree to
+// prune" rather than an error -- it simply stops visiting this one entry's
+// (nonexistent) children.
+func visitChild(fn WalkFunc, entry WalkEntry) error {
+	if err := fn(entry); err != nil && !errors.Is(err, SkipSubtree) {
+		return err
+	}
+	return nil
+}
+
+func itemsByHostID(ctx context.Context, zc *zabbix.Client, hostIDs []string) (map[string][]zabbix.Item, error) {
+	items, err := zc.ItemGet(ctx, zabbix.ItemGetParams{Output: "extend", HostIDs: hostIDs})
+	if err != nil {
+		return nil, fmt.Errorf("item.get: %w", err)
+	}
+	byHost := make(map[string][]zabbix.Item)
+	for _, item := range items {
+		byHost[item.HostID] = append(byHost[item.HostID], item)
+	}
+	return byHost, nil
+}
+
+func triggersByHostID(ctx context.Context, zc *zabbix.Client, hostIDs []string) (map[string][]zabbix.Trigger, error) {
+	triggers, err := zc.TriggerGet(ctx, zabbix.TriggerGetParams{Output: "extend", HostIDs: hostIDs, SelectHosts: []string{"hostid"}})
+	if err != nil {
+		return nil, fmt.Errorf("trigger.get: %w", err)
+	}
+	byHost := make(map[string][]zabbix.Trigger)
+	for _, trigger := range triggers {
+		for _, h := range trigger.Hosts {
+			byHost[h.HostID] = append(byHost[h.HostID], trigger)
+		}
+	}
+	return byHost, nil
+}
+
+func graphsByHostID(ctx context.Context, zc *zabbix.Client, hostIDs []string) (map[string][]zabbix.Graph, error) {
+	graphs, err := zc.GraphGet(ctx, zabbix.GraphGetParams{Output: "extend", HostIDs: hostIDs, SelectHosts: []string{"hostid"}})
+	if err != nil {
+		return nil, fmt.Errorf("graph.get: %w", err)
+	}
+	byHost := make(map[string][]zabbix.Graph)
+	for _, graph := range graphs {
+		for _, h := range graph.Hosts {
+			byHost[h.HostID] = append(byHost[h.HostID], graph)
+		}
+	}
+	return byHost, nil
+}
+
+func lldRulesByHostID(ctx context.Context, zc *zabbix.Client, hostIDs []string) (map[string][]zabbix.LLDRule, error) {
+	rules, err := zc.LLDRuleGet(ctx, zabbix.LLDRuleGetParams{Output: "extend", HostIDs: hostIDs})
+	if err != nil {
+		return nil, fmt.Errorf("discoveryrule.get: %w", err)
+	}
+	byHost := make(map[string][]zabbix.LLDRule)
+	for _, rule := range rules {
+		byHost[rule.HostID] = append(byHost[rule.HostID], rule)
+	}
+	return byHost, nil
+}
@@ -0,0 +1,177 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/Norisaline/golang-zabbix-config/pkg/zabbix"
+)
+
+// fakeRPC serves the handful of JSON-RPC methods Walk needs, each returning
+// a fixed, pre-canned result regardless of the request's params -- Walk
+// does its own grouping of items/triggers/graphs/rules by HostID locally,
+// so the fake only needs to hand back data tagged with the right hostid,
+// not actually honour filters.
+type fakeRPC struct {
+	t       *testing.T
+	results map[string]interface{}
+}
+
+func (f *fakeRPC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string `json:"method"`
+		ID     int    `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.t.Fatalf("decoding request: %v", err)
+	}
+
+	result, ok := f.results[req.Method]
+	if !ok {
+		f.t.Fatalf("unexpected RPC method %q", req.Method)
+	}
+
+	w.Header().Set("Content-Type", "application/json-rpc")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"result":  result,
+		"id":      req.ID,
+	})
+}
+
+func newTestClient(t *testing.T, results map[string]interface{}) *zabbix.Client {
+	t.Helper()
+	srv := httptest.NewServer(&fakeRPC{t: t, results: results})
+	t.Cleanup(srv.Close)
+	return zabbix.NewClient(resty.New(), srv.URL, nil)
+}
+
+func testFixtures() map[string]interface{} {
+	return map[string]interface{}{
+		"hostgroup.get": []zabbix.HostGroup{{GroupID: "1", Name: "Linux servers"}},
+		"host.get": []zabbix.Host{{
+			HostID:          "10",
+			Name:            "web01",
+			ParentTemplates: []zabbix.Template{{TemplateID: "100", Name: "Template OS Linux"}},
+			Interfaces:      []zabbix.Interface{{InterfaceID: "1000", IP: "10.0.0.1"}},
+		}},
+		"item.get":          []zabbix.Item{{ItemID: "500", HostID: "10", Key: "system.cpu.load"}},
+		"trigger.get":       []zabbix.Trigger{{TriggerID: "600", Hosts: []zabbix.HostRef{{HostID: "10"}}}},
+		"graph.get":         []zabbix.Graph{{GraphID: "700", Hosts: []zabbix.HostRef{{HostID: "10"}}}},
+		"discoveryrule.get": []zabbix.LLDRule{{ItemID: "800", HostID: "10"}},
+	}
+}
+
+func TestWalkVisitsEveryNodeInOrder(t *testing.T) {
+	zc := newTestClient(t, testFixtures())
+
+	var kinds []Kind
+	err := Walk(context.Background(), zc, WalkRoot{}, func(e WalkEntry) error {
+		kinds = append(kinds, e.Kind)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+
+	want := []Kind{
+		KindHostGroup,
+		KindHost,
+		KindTemplate,
+		KindInterface,
+		KindItem,
+		KindTrigger,
+		KindGraph,
+		KindLLDRule,
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("visited %v, want %v", kinds, want)
+	}
+	for i, k := range want {
+		if kinds[i] != k {
+			t.Fatalf("visited %v, want %v", kinds, want)
+		}
+	}
+}
+
+func TestWalkSkipSubtreeOnHostGroupSkipsItsHosts(t *testing.T) {
+	zc := newTestClient(t, testFixtures())
+
+	var kinds []Kind
+	err := Walk(context.Background(), zc, WalkRoot{}, func(e WalkEntry) error {
+		kinds = append(kinds, e.Kind)
+		if e.Kind == KindHostGroup {
+			return SkipSubtree
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(kinds) != 1 || kinds[0] != KindHostGroup {
+		t.Fatalf("visited %v, want only [%v]", kinds, KindHostGroup)
+	}
+}
+
+func TestWalkSkipSubtreeOnHostSkipsItsChildren(t *testing.T) {
+	zc := newTestClient(t, testFixtures())
+
+	var kinds []Kind
+	err := Walk(context.Background(), zc, WalkRoot{}, func(e WalkEntry) error {
+		kinds = append(kinds, e.Kind)
+		if e.Kind == KindHost {
+			return SkipSubtree
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	want := []Kind{KindHostGroup, KindHost}
+	if len(kinds) != len(want) {
+		t.Fatalf("visited %v, want %v", kinds, want)
+	}
+	for i, k := range want {
+		if kinds[i] != k {
+			t.Fatalf("visited %v, want %v", kinds, want)
+		}
+	}
+}
+
+func TestWalkHostFilterExcludesRejectedHosts(t *testing.T) {
+	zc := newTestClient(t, testFixtures())
+
+	var kinds []Kind
+	root := WalkRoot{HostFilter: func(zabbix.Host) bool { return false }}
+	err := Walk(context.Background(), zc, root, func(e WalkEntry) error {
+		kinds = append(kinds, e.Kind)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(kinds) != 1 || kinds[0] != KindHostGroup {
+		t.Fatalf("visited %v, want only [%v] once HostFilter rejects every host", kinds, KindHostGroup)
+	}
+}
+
+func TestWalkPropagatesFnError(t *testing.T) {
+	zc := newTestClient(t, testFixtures())
+
+	boom := errTestWalk("boom")
+	err := Walk(context.Background(), zc, WalkRoot{}, func(e WalkEntry) error {
+		return boom
+	})
+	if err != boom {
+		t.Fatalf("Walk returned %v, want %v", err, boom)
+	}
+}
+
+type errTestWalk string
+
+func (e errTestWalk) Error() string { return string(e) }
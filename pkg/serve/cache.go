@@ -0,0 +1,134 @@
+// Package serve turns the one-shot exporter into a queryable cache: it
+// keeps the collected inventory in memory, refreshed on an interval or on
+// demand, and exposes it over HTTP (and, per proto/inventory.proto, gRPC).
+package serve
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Norisaline/golang-zabbix-config/pkg/zabbix"
+)
+
+// Cache holds the most recently collected inventory in memory so read
+// requests never block on the Zabbix API.
+type Cache struct {
+	zc *zabbix.Client
+
+	mu       sync.RWMutex
+	hosts    []zabbix.Host
+	metrics  map[string][]zabbix.Item
+	triggers map[string][]zabbix.Trigger
+}
+
+// NewCache returns an empty Cache backed by zc. Call Refresh (or Run) before
+// serving any request.
+func NewCache(zc *zabbix.Client) *Cache {
+	return &Cache{zc: zc}
+}
+
+// Refresh re-fetches the full inventory from Zabbix and swaps it in
+// atomically, so concurrent readers never see a partially-updated cache.
+func (c *Cache) Refresh(ctx context.Context) error {
+	hosts, err := c.zc.HostGet(ctx, zabbix.HostGetParams{
+		Output:           "extend",
+		SelectGroups:     "extend",
+		SelectTemplates:  "extend",
+		SelectInterfaces: "extend",
+	})
+	if err != nil {
+		return err
+	}
+
+	hostIDs := make([]string, len(hosts))
+	for i, h := range hosts {
+		hostIDs[i] = h.HostID
+	}
+
+	items, err := c.zc.ItemGet(ctx, zabbix.ItemGetParams{Output: "extend", HostIDs: hostIDs})
+	if err != nil {
+		return err
+	}
+	triggers, err := c.zc.TriggerGet(ctx, zabbix.TriggerGetParams{Output: "extend", HostIDs: hostIDs, SelectHosts: []string{"hostid"}})
+	if err != nil {
+		return err
+	}
+
+	metricsByHost := make(map[string][]zabbix.Item)
+	for _, item := range items {
+		metricsByHost[item.HostID] = append(metricsByHost[item.HostID], item)
+	}
+	triggersByHost := make(map[string][]zabbix.Trigger)
+	for _, trigger := range triggers {
+		for _, h := range trigger.Hosts {
+			triggersByHost[h.HostID] = append(triggersByHost[h.HostID], trigger)
+		}
+	}
+
+	c.mu.Lock()
+	c.hosts = hosts
+	c.metrics = metricsByHost
+	c.triggers = triggersByHost
+	c.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the cache once, then again every interval until ctx is
+// done. A failed refresh is logged and the previous snapshot kept.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	if err := c.Refresh(ctx); err != nil {
+		log.Printf("serve: первоначальное обновление кэша: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				log.Printf("serve: обновление кэша: %v", err)
+			}
+		}
+	}
+}
+
+func (c *Cache) Hosts() []zabbix.Host {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]zabbix.Host(nil), c.hosts...)
+}
+
+// HostMetrics returns the cached metrics for hostID, and whether that host
+// is known at all (as opposed to known-but-metric-less).
+func (c *Cache) HostMetrics(hostID string) ([]zabbix.Item, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.hasHost(hostID) {
+		return nil, false
+	}
+	return c.metrics[hostID], true
+}
+
+// HostTriggers returns the cached triggers for hostID, and whether that
+// host is known at all.
+func (c *Cache) HostTriggers(hostID string) ([]zabbix.Trigger, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.hasHost(hostID) {
+		return nil, false
+	}
+	return c.triggers[hostID], true
+}
+
+func (c *Cache) hasHost(hostID string) bool {
+	for _, h := range c.hosts {
+		if h.HostID == hostID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// GRPCServer implements the Inventory service defined in
+// proto/inventory.proto against the same Cache the HTTP handlers read
+// from.
+//
+// This tree has no protoc toolchain available, so the generated stubs
+// (inventorypb.UnimplementedInventoryServer, inventorypb.HostSummary, the
+// grpc.ServiceDesc, ...) aren't checked in here. In their place,
+// inventoryServiceDesc below is a hand-rolled grpc.ServiceDesc binding the
+// same three RPCs to the message types below, encoded with the "json"
+// codec registered in init() instead of protobuf wire format. Once
+// `protoc --go_out=. --go-grpc_out=. proto/inventory.proto` is run, swap
+// the types for the inventorypb equivalents, embed
+// inventorypb.UnimplementedInventoryServer in GRPCServer, and register it
+// with grpc.NewServer() the usual way -- the method bodies need no
+// changes, and ServeGRPC's caller doesn't either.
+type GRPCServer struct {
+	cache *Cache
+}
+
+// NewGRPCServer returns a GRPCServer reading from cache.
+func NewGRPCServer(cache *Cache) *GRPCServer {
+	return &GRPCServer{cache: cache}
+}
+
+type ListHostsRequest struct{}
+
+type HostSummary struct {
+	HostID string
+	Name   string
+	Status string
+}
+
+type ListHostsResponse struct {
+	Hosts []HostSummary
+}
+
+type GetHostRequest struct {
+	HostID string
+}
+
+type MetricSummary struct {
+	ItemID    string
+	Name      string
+	Key       string
+	LastValue string
+}
+
+type GetHostMetricsResponse struct {
+	Metrics []MetricSummary
+}
+
+type TriggerSummary struct {
+	TriggerID   string
+	Description string
+	Priority    string
+	Status      string
+}
+
+type GetHostTriggersResponse struct {
+	Triggers []TriggerSummary
+}
+
+func (s *GRPCServer) ListHosts(ctx context.Context, _ *ListHostsRequest) (*ListHostsResponse, error) {
+	hosts := s.cache.Hosts()
+	resp := &ListHostsResponse{Hosts: make([]HostSummary, len(hosts))}
+	for i, h := range hosts {
+		resp.Hosts[i] = HostSummary{HostID: h.HostID, Name: h.Name, Status: h.Status}
+	}
+	return resp, nil
+}
+
+func (s *GRPCServer) GetHostMetrics(ctx context.Context, req *GetHostRequest) (*GetHostMetricsResponse, error) {
+	items, ok := s.cache.HostMetrics(req.HostID)
+	if !ok {
+		return nil, fmt.Errorf("serve: хост %s не найден", req.HostID)
+	}
+	resp := &GetHostMetricsResponse{Metrics: make([]MetricSummary, len(items))}
+	for i, item := range items {
+		resp.Metrics[i] = MetricSummary{ItemID: item.ItemID, Name: item.Name, Key: item.Key, LastValue: item.LastValue}
+	}
+	return resp, nil
+}
+
+func (s *GRPCServer) GetHostTriggers(ctx context.Context, req *GetHostRequest) (*GetHostTriggersResponse, error) {
+	triggers, ok := s.cache.HostTriggers(req.HostID)
+	if !ok {
+		return nil, fmt.Errorf("serve: хост %s не найден", req.HostID)
+	}
+	resp := &GetHostTriggersResponse{Triggers: make([]TriggerSummary, len(triggers))}
+	for i, t := range triggers {
+		resp.Triggers[i] = TriggerSummary{TriggerID: t.TriggerID, Description: t.Description, Priority: t.Priority, Status: t.Status}
+	}
+	return resp, nil
+}
+
+// jsonCodec marshals gRPC messages as JSON rather than protobuf, since the
+// message types above aren't proto.Message implementations generated by
+// protoc. Registered under the name "json" so a client opts in by dialing
+// with grpc.CallContentSubtype("json").
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+func (jsonCodec) Name() string { return "json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// inventoryServiceDesc binds the Inventory RPCs from proto/inventory.proto
+// to GRPCServer by hand, in place of the grpc.ServiceDesc protoc-gen-go-grpc
+// would normally generate.
+var inventoryServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.Inventory",
+	HandlerType: (*GRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListHosts", Handler: listHostsHandler},
+		{MethodName: "GetHostMetrics", Handler: getHostMetricsHandler},
+		{MethodName: "GetHostTriggers", Handler: getHostTriggersHandler},
+	},
+	Metadata: "proto/inventory.proto",
+}
+
+func listHostsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(*GRPCServer).ListHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/inventory.Inventory/ListHosts"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(*GRPCServer).ListHosts(ctx, req.(*ListHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getHostMetricsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(*GRPCServer).GetHostMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/inventory.Inventory/GetHostMetrics"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(*GRPCServer).GetHostMetrics(ctx, req.(*GetHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getHostTriggersHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(*GRPCServer).GetHostTriggers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/inventory.Inventory/GetHostTriggers"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(*GRPCServer).GetHostTriggers(ctx, req.(*GetHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ServeGRPC starts a gRPC listener at addr exposing cache via the
+// Inventory service, until ctx is cancelled, mirroring ListenAndServe's
+// lifecycle for the HTTP handlers.
+func ServeGRPC(ctx context.Context, cache *Cache, addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("serve: gRPC listener на %s: %w", addr, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	grpcServer.RegisterService(&inventoryServiceDesc, NewGRPCServer(cache))
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
+	if err := grpcServer.Serve(lis); err != nil {
+		return fmt.Errorf("serve: gRPC сервер: %w", err)
+	}
+	return nil
+}
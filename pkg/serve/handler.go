@@ -0,0 +1,81 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxBodyBytes bounds every request body, even though today's endpoints are
+// read-only -- cheap insurance against a future POST handler accepting
+// unbounded input.
+const maxBodyBytes = 1 << 20 // 1 MiB
+
+// requestTimeout bounds how long a single HTTP request may take end to end.
+const requestTimeout = 10 * time.Second
+
+// handlerFunc is what every endpoint implements: given a request-scoped
+// context, produce a value to encode, or an error to map to a status code.
+type handlerFunc func(ctx context.Context, r *http.Request) (interface{}, error)
+
+// notFoundError marks a handlerFunc error as a 404 rather than a 500.
+type notFoundError struct{ msg string }
+
+func (e notFoundError) Error() string { return e.msg }
+
+// wrap turns a handlerFunc into an http.HandlerFunc: it caps the request
+// body, attaches a request deadline, runs fn, and maps the result to an
+// HTTP response -- encoding success values per content negotiation,
+// mapping errors to a status code -- so individual endpoints don't repeat
+// that plumbing.
+func wrap(fn handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+
+		data, err := fn(ctx, r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeContent(w, r, data)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if _, ok := err.(notFoundError); ok {
+		status = http.StatusNotFound
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// writeContent encodes data as JSON, XML or YAML depending on the Accept
+// header, defaulting to JSON.
+func writeContent(w http.ResponseWriter, r *http.Request, data interface{}) {
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "application/xml"):
+		w.Header().Set("Content-Type", "application/xml")
+		if err := xml.NewEncoder(w).Encode(data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case strings.Contains(accept, "yaml"):
+		w.Header().Set("Content-Type", "application/yaml")
+		if err := yaml.NewEncoder(w).Encode(data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Routes returns the HTTP handler exposing the cached inventory:
+// GET /hosts, GET /hosts/{id}/metrics, GET /hosts/{id}/triggers, and
+// POST /refresh to refresh the cache on demand (e.g. from a webhook)
+// instead of waiting for the next interval.
+func Routes(cache *Cache) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/hosts", wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		return cache.Hosts(), nil
+	}))
+
+	mux.HandleFunc("/hosts/", wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		hostID, sub, ok := splitHostPath(r.URL.Path)
+		if !ok {
+			return nil, notFoundError{"неизвестный маршрут"}
+		}
+		switch sub {
+		case "metrics":
+			metrics, ok := cache.HostMetrics(hostID)
+			if !ok {
+				return nil, notFoundError{fmt.Sprintf("хост %s не найден", hostID)}
+			}
+			return metrics, nil
+		case "triggers":
+			triggers, ok := cache.HostTriggers(hostID)
+			if !ok {
+				return nil, notFoundError{fmt.Sprintf("хост %s не найден", hostID)}
+			}
+			return triggers, nil
+		default:
+			return nil, notFoundError{"неизвестный маршрут"}
+		}
+	}))
+
+	mux.HandleFunc("/refresh", wrap(func(ctx context.Context, r *http.Request) (interface{}, error) {
+		if r.Method != http.MethodPost {
+			return nil, notFoundError{"только POST"}
+		}
+		if err := cache.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		return map[string]string{"status": "ok"}, nil
+	}))
+
+	return mux
+}
+
+// splitHostPath parses "/hosts/{id}/{sub}" into its two parts.
+func splitHostPath(path string) (id, sub string, ok bool) {
+	trimmed := strings.TrimPrefix(path, "/hosts/")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
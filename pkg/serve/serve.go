@@ -0,0 +1,26 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ListenAndServe starts cache's background refresh loop and serves the
+// HTTP inventory API at addr until ctx is cancelled.
+func ListenAndServe(ctx context.Context, cache *Cache, addr string, refreshInterval time.Duration) error {
+	go cache.Run(ctx, refreshInterval)
+
+	srv := &http.Server{Addr: addr, Handler: Routes(cache)}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
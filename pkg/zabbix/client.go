@@ -0,0 +1,167 @@
+// Package zabbix is a typed client for the Zabbix JSON-RPC API. It replaces
+// ad-hoc map[string]interface{} request bodies and result type assertions
+// with generated-style request/response structs, so a schema change (like
+// Zabbix 6.0 moving "available" from the host to its interfaces) is a
+// compile error here instead of a runtime panic at the call site.
+//
+// The *_types.gen.go files are generated from schema/entities.json by
+// internal/gen -- the same role protoc plays for a gRPC client. Run `go
+// generate ./...` after editing the schema; don't hand-edit a .gen.go
+// file.
+package zabbix
+
+//go:generate go run ./internal/gen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/Norisaline/golang-zabbix-config/pkg/auth"
+)
+
+// RequestTimeout bounds every individual JSON-RPC call, enforced
+// independently of any client-wide HTTP timeout via deadlineTimer.
+const RequestTimeout = 30 * time.Second
+
+// Client is a thin, typed wrapper around a single Zabbix JSON-RPC endpoint.
+// It is safe for concurrent use once authenticated.
+type Client struct {
+	HTTP   *resty.Client
+	Server string
+	Auth   auth.Authenticator
+
+	mu   sync.RWMutex
+	cred auth.Credential
+}
+
+// NewClient returns a Client for server using http as the transport and
+// authenticator to establish (and, if supported, refresh) credentials.
+// Call Authenticate before issuing any other request.
+func NewClient(http *resty.Client, server string, authenticator auth.Authenticator) *Client {
+	return &Client{HTTP: http, Server: server, Auth: authenticator}
+}
+
+// Authenticate runs Auth and stores the resulting credential.
+func (c *Client) Authenticate(ctx context.Context) error {
+	cred, err := c.Auth.Authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf("zabbix: аутентификация: %w", err)
+	}
+	c.mu.Lock()
+	c.cred = cred
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Client) credential() auth.Credential {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cred
+}
+
+// refresh asks Auth for a new credential, if it supports refreshing one.
+// It reports whether a new credential was installed.
+func (c *Client) refresh(ctx context.Context) bool {
+	refresher, ok := c.Auth.(auth.Refresher)
+	if !ok {
+		return false
+	}
+	cred, err := refresher.Refresh(ctx)
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	c.cred = cred
+	c.mu.Unlock()
+	return true
+}
+
+type rpcResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *Error          `json:"error"`
+	ID      int             `json:"id"`
+}
+
+// call issues a JSON-RPC request for method with params, decoding the
+// result into out (skipped if out is nil). If the server reports the
+// session as terminated, it asks Auth to refresh once and retries before
+// giving up.
+func (c *Client) call(ctx context.Context, method string, params, out interface{}) error {
+	return c.callAttempt(ctx, method, params, out, true)
+}
+
+func (c *Client) callAttempt(ctx context.Context, method string, params, out interface{}, retryOnExpiry bool) error {
+	body := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	}
+
+	resp, err := c.post(ctx, body)
+	if err != nil {
+		return fmt.Errorf("zabbix: %s: %w", method, err)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(resp.Body(), &rpcResp); err != nil {
+		return fmt.Errorf("zabbix: %s: разбор ответа: %w", method, err)
+	}
+	if rpcResp.Error != nil && rpcResp.Error.Code != 0 {
+		if retryOnExpiry && auth.IsSessionTerminated(rpcResp.Error) && c.refresh(ctx) {
+			return c.callAttempt(ctx, method, params, out, false)
+		}
+		return rpcResp.Error
+	}
+	if out == nil || len(rpcResp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("zabbix: %s: разбор результата: %w", method, err)
+	}
+	return nil
+}
+
+// post sends body to the Zabbix endpoint, aborting the request if it
+// doesn't complete before RequestTimeout. The HTTP call selects on both the
+// resty request context and the deadline's cancel channel, so the request
+// is abandoned as soon as either fires. The current credential, if any, is
+// applied to the request just before it's sent.
+func (c *Client) post(ctx context.Context, body map[string]interface{}) (*resty.Response, error) {
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	dt := newDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(RequestTimeout))
+
+	type result struct {
+		resp *resty.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		req := c.HTTP.R().
+			SetContext(reqCtx).
+			SetHeader("Content-Type", "application/json-rpc")
+		if cred := c.credential(); cred != nil {
+			cred.Apply(req, body)
+		}
+		resp, err := req.SetBody(body).Post(c.Server)
+		done <- result{resp, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.resp, r.err
+	case <-dt.readCancel():
+		cancel()
+		<-done
+		return nil, fmt.Errorf("запрос к %s превысил дедлайн %s", c.Server, RequestTimeout)
+	}
+}
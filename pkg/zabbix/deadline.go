@@ -0,0 +1,72 @@
+package zabbix
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer arms a cancellation channel that closes when a deadline
+// elapses, modelled on the deadlineTimer used by netstack's endpoints for
+// read/write deadlines. Each in-flight request reads the current cancel
+// channel once and selects on it. Moving a deadline that hasn't elapsed yet
+// keeps the same channel, so a caller already selecting on it sees the new
+// deadline; only once a deadline has fired and closed its channel does the
+// next SetDeadline swap in a fresh one, which callers must re-read.
+type deadlineTimer struct {
+	mu     sync.Mutex
+	timer  *time.Timer
+	cancel chan struct{}
+}
+
+// newDeadlineTimer returns a deadlineTimer with no deadline set.
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{cancel: make(chan struct{})}
+}
+
+// readCancel returns the channel that closes when the current deadline
+// elapses. It never returns a already-fired channel from a previous,
+// superseded deadline.
+func (d *deadlineTimer) readCancel() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancel
+}
+
+// SetDeadline arms the timer to close the cancel channel at t. A zero t
+// clears the deadline. A t that has already passed closes the channel
+// immediately.
+func (d *deadlineTimer) SetDeadline(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		if !d.timer.Stop() {
+			// The timer already fired and closed the old channel; a fresh
+			// one is needed so the new deadline isn't observed as already
+			// expired.
+			d.cancel = make(chan struct{})
+		}
+		d.timer = nil
+	}
+
+	select {
+	case <-d.cancel:
+		d.cancel = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	if !t.After(now) {
+		close(d.cancel)
+		return
+	}
+
+	cancel := d.cancel
+	d.timer = time.AfterFunc(t.Sub(now), func() {
+		close(cancel)
+	})
+}
@@ -0,0 +1,100 @@
+package zabbix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineTimerFiresAtDeadline(t *testing.T) {
+	dt := newDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(20 * time.Millisecond))
+
+	select {
+	case <-dt.readCancel():
+	case <-time.After(time.Second):
+		t.Fatal("deadline did not fire within 1s")
+	}
+}
+
+func TestDeadlineTimerPastDeadlineFiresImmediately(t *testing.T) {
+	dt := newDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(-time.Second))
+
+	select {
+	case <-dt.readCancel():
+	default:
+		t.Fatal("a deadline already in the past should close the channel immediately")
+	}
+}
+
+func TestDeadlineTimerZeroClearsDeadline(t *testing.T) {
+	dt := newDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(20 * time.Millisecond))
+	dt.SetDeadline(time.Time{})
+
+	select {
+	case <-dt.readCancel():
+		t.Fatal("zero deadline should clear any pending deadline")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestDeadlineTimerExtendPendingDeadline covers the common mid-flight
+// change: SetDeadline moves an armed, not-yet-elapsed deadline further out.
+// Since the old timer is stopped before it ever fired, readCancel keeps
+// returning the same channel -- a caller that already read it still sees
+// the extension, it just now fires at the new time instead of the old one.
+func TestDeadlineTimerExtendPendingDeadline(t *testing.T) {
+	dt := newDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(150 * time.Millisecond))
+	before := dt.readCancel()
+
+	dt.SetDeadline(time.Now().Add(300 * time.Millisecond))
+	after := dt.readCancel()
+
+	if before != after {
+		t.Fatal("extending a still-pending deadline must keep the same cancel channel")
+	}
+
+	select {
+	case <-after:
+		t.Fatal("deadline fired before the extended time")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	select {
+	case <-after:
+	case <-time.After(time.Second):
+		t.Fatal("extended deadline never fired")
+	}
+}
+
+// TestDeadlineTimerRearmAfterExpiry covers the other case readCancel's doc
+// comment calls out: once a deadline has already elapsed and closed its
+// channel, a subsequent SetDeadline must swap in a fresh one rather than
+// reuse the permanently-closed one, so the new deadline isn't observed as
+// already expired.
+func TestDeadlineTimerRearmAfterExpiry(t *testing.T) {
+	dt := newDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(20 * time.Millisecond))
+
+	expired := dt.readCancel()
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Fatal("deadline did not fire within 1s")
+	}
+
+	dt.SetDeadline(time.Now().Add(20 * time.Millisecond))
+	fresh := dt.readCancel()
+
+	if expired == fresh {
+		t.Fatal("re-arming after expiry should swap in a new cancel channel")
+	}
+
+	select {
+	case <-fresh:
+	case <-time.After(time.Second):
+		t.Fatal("re-armed deadline never fired")
+	}
+}
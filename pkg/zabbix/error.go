@@ -0,0 +1,35 @@
+package zabbix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error wraps a Zabbix JSON-RPC error, preserving the server's numeric Code
+// and the human-readable Data alongside Message so callers can distinguish
+// e.g. an expired session (-32602) from a permissions failure.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *Error) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("zabbix: %s (code %d): %s", e.Message, e.Code, e.Data)
+	}
+	return fmt.Sprintf("zabbix: %s (code %d)", e.Message, e.Code)
+}
+
+// sessionTerminatedText is the Data Zabbix sends alongside code -32602 when
+// the session itself has expired. The same code is reused for every other
+// "Invalid params" validation failure (bad hostid, bad filter, ...), so the
+// code alone can't distinguish a dead session from a bad request.
+const sessionTerminatedText = "Session terminated, re-login, please"
+
+// SessionTerminated reports whether this is Zabbix's "Session terminated,
+// re-login, please" response, which the auth package's Authenticators can
+// recover from via Refresh.
+func (e *Error) SessionTerminated() bool {
+	return e.Code == -32602 && (strings.Contains(e.Data, sessionTerminatedText) || strings.Contains(e.Message, sessionTerminatedText))
+}
@@ -0,0 +1,13 @@
+package zabbix
+
+import "context"
+
+// GraphGet calls graph.get and returns the typed graphs it finds.
+// GraphGetParams and Graph are generated; see graph_types.gen.go.
+func (c *Client) GraphGet(ctx context.Context, params GraphGetParams) ([]Graph, error) {
+	var graphs []Graph
+	if err := c.call(ctx, "graph.get", params, &graphs); err != nil {
+		return nil, err
+	}
+	return graphs, nil
+}
@@ -0,0 +1,13 @@
+package zabbix
+
+import "context"
+
+// HostGet calls host.get and returns the typed hosts it finds. HostGetParams
+// and Host are generated; see host_types.gen.go.
+func (c *Client) HostGet(ctx context.Context, params HostGetParams) ([]Host, error) {
+	var hosts []Host
+	if err := c.call(ctx, "host.get", params, &hosts); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
@@ -0,0 +1,14 @@
+package zabbix
+
+import "context"
+
+// HostGroupGet calls hostgroup.get and returns the typed groups it finds.
+// HostGroupGetParams and HostGroup are generated; see
+// hostgroup_types.gen.go.
+func (c *Client) HostGroupGet(ctx context.Context, params HostGroupGetParams) ([]HostGroup, error) {
+	var groups []HostGroup
+	if err := c.call(ctx, "hostgroup.get", params, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
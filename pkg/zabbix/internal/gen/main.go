@@ -0,0 +1,83 @@
+// Command gen reads pkg/zabbix/schema/entities.json and writes the
+// request/response structs for each Zabbix JSON-RPC entity to
+// pkg/zabbix/<entity>_types.gen.go. It's invoked via `go generate` from
+// pkg/zabbix/client.go -- see that file's go:generate directive -- and
+// plays the same role for this client that protoc plays for a gRPC one:
+// turning a schema into the generated-style structs the hand-written
+// *Get methods build on.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+type field struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Tag       string `json:"tag"`
+	OmitEmpty bool   `json:"omitempty"`
+}
+
+type structDef struct {
+	Name   string  `json:"name"`
+	Doc    string  `json:"doc"`
+	Fields []field `json:"fields"`
+}
+
+type entityFile struct {
+	OutFile string      `json:"outFile"`
+	Structs []structDef `json:"structs"`
+}
+
+const fileTemplate = `// Code generated by "go generate" from schema/entities.json; DO NOT EDIT.
+
+package zabbix
+{{range .Structs}}
+{{if .Doc}}// {{.Doc}}
+{{end}}type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}} ` + "`json:\"{{.Tag}}{{if .OmitEmpty}},omitempty{{end}}\"`" + `
+{{end}}}
+{{end}}`
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	data, err := os.ReadFile(filepath.Join("schema", "entities.json"))
+	if err != nil {
+		return fmt.Errorf("чтение схемы: %w", err)
+	}
+
+	var files []entityFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return fmt.Errorf("разбор схемы: %w", err)
+	}
+
+	tmpl := template.Must(template.New("entity").Parse(fileTemplate))
+	for _, f := range files {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, f); err != nil {
+			return fmt.Errorf("%s: рендеринг шаблона: %w", f.OutFile, err)
+		}
+
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: gofmt сгенерированного кода: %w", f.OutFile, err)
+		}
+
+		if err := os.WriteFile(f.OutFile, formatted, 0644); err != nil {
+			return fmt.Errorf("%s: запись файла: %w", f.OutFile, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package zabbix
+
+import "context"
+
+// ItemGet calls item.get and returns the typed items it finds.
+// ItemGetParams and Item are generated; see item_types.gen.go.
+func (c *Client) ItemGet(ctx context.Context, params ItemGetParams) ([]Item, error) {
+	var items []Item
+	if err := c.call(ctx, "item.get", params, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
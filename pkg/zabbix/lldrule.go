@@ -0,0 +1,13 @@
+package zabbix
+
+import "context"
+
+// LLDRuleGet calls discoveryrule.get and returns the typed rules it finds.
+// LLDRuleGetParams and LLDRule are generated; see lldrule_types.gen.go.
+func (c *Client) LLDRuleGet(ctx context.Context, params LLDRuleGetParams) ([]LLDRule, error) {
+	var rules []LLDRule
+	if err := c.call(ctx, "discoveryrule.get", params, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,13 @@
+package zabbix
+
+import "context"
+
+// TriggerGet calls trigger.get and returns the typed triggers it finds.
+// TriggerGetParams and Trigger are generated; see trigger_types.gen.go.
+func (c *Client) TriggerGet(ctx context.Context, params TriggerGetParams) ([]Trigger, error) {
+	var triggers []Trigger
+	if err := c.call(ctx, "trigger.get", params, &triggers); err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
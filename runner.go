@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/Norisaline/golang-zabbix-config/pkg/export"
+	"github.com/Norisaline/golang-zabbix-config/pkg/zabbix"
+)
+
+// defaultExportWorkers is used when ZBX_EXPORT_WORKERS is unset or invalid.
+const defaultExportWorkers = 4
+
+// exportWorkerCount reads the worker-pool size from ZBX_EXPORT_WORKERS.
+func exportWorkerCount() int {
+	raw := os.Getenv("ZBX_EXPORT_WORKERS")
+	if raw == "" {
+		return defaultExportWorkers
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return defaultExportWorkers
+	}
+	return n
+}
+
+// hostResult records the outcome of exporting a single host, so one failing
+// host can be reported without aborting the rest of the pool.
+type hostResult struct {
+	HostID   string
+	HostName string
+	Err      error
+}
+
+// runExportPool exports hosts through a fixed-size worker pool, each worker
+// pulling the next host off jobs and exporting its details, metrics and
+// triggers. It returns one hostResult per host, in completion order.
+func runExportPool(ctx context.Context, zc *zabbix.Client, sink export.Exporter, hosts []zabbix.Host, workers int) []hostResult {
+	jobs := make(chan zabbix.Host)
+	results := make(chan hostResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				results <- hostResult{
+					HostID:   host.HostID,
+					HostName: host.Name,
+					Err:      exportHost(ctx, zc, sink, host),
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, host := range hosts {
+			jobs <- host
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var summary []hostResult
+	for r := range results {
+		summary = append(summary, r)
+	}
+	return summary
+}
+
+// exportHost exports one host's details, metrics and triggers. It returns
+// the first error encountered, but always attempts all three so a failure
+// in one doesn't suppress data the others collected.
+func exportHost(ctx context.Context, zc *zabbix.Client, sink export.Exporter, host zabbix.Host) error {
+	var firstErr error
+	note := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	exportable := toExportHost(host)
+	if err := sink.Export(export.Payload{Kind: export.KindHost, HostID: exportable.HostID, HostName: exportable.HostName, Data: exportable}); err != nil {
+		note(fmt.Errorf("экспорт хоста %s: %w", exportable.HostName, err))
+	}
+	note(exportMetricsForHost(ctx, zc, sink, host.HostID, host.Name))
+	note(exportTriggersForHost(ctx, zc, sink, host.HostID, host.Name))
+
+	return firstErr
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Norisaline/golang-zabbix-config/pkg/serve"
+	"github.com/Norisaline/golang-zabbix-config/pkg/zabbix"
+)
+
+const (
+	defaultServeAddr       = ":8080"
+	defaultGRPCAddr        = ":9090"
+	defaultRefreshInterval = 5 * time.Minute
+)
+
+// runServe implements the `serve` subcommand: keep the collected inventory
+// in memory, refreshed on ZBX_REFRESH_INTERVAL, and expose it over HTTP at
+// ZBX_SERVE_ADDR and gRPC at ZBX_GRPC_ADDR. Returns once both listeners
+// have stopped -- which happens when ctx is cancelled, or as soon as either
+// listener fails, at which point the other is stopped too instead of being
+// left running unattended.
+func runServe(ctx context.Context, zc *zabbix.Client) error {
+	addr := os.Getenv("ZBX_SERVE_ADDR")
+	if addr == "" {
+		addr = defaultServeAddr
+	}
+	grpcAddr := os.Getenv("ZBX_GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = defaultGRPCAddr
+	}
+
+	interval := defaultRefreshInterval
+	if raw := os.Getenv("ZBX_REFRESH_INTERVAL"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds <= 0 {
+			return fmt.Errorf("некорректный ZBX_REFRESH_INTERVAL %q", raw)
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	cache := serve.NewCache(zc)
+
+	// runCtx is cancelled either when ctx is (normal shutdown) or as soon as
+	// one listener fails, so a failure in one doesn't leave the other
+	// running unattended.
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	run := func(name string, fn func() error) {
+		defer wg.Done()
+		if err := fn(); err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			mu.Unlock()
+			cancel()
+		}
+	}
+
+	wg.Add(2)
+	go func() {
+		fmt.Printf("HTTP сервер инвентаря слушает %s (обновление каждые %s)\n", addr, interval)
+		run("http", func() error { return serve.ListenAndServe(runCtx, cache, addr, interval) })
+	}()
+	go func() {
+		fmt.Printf("gRPC сервер инвентаря слушает %s\n", grpcAddr)
+		run("grpc", func() error { return serve.ServeGRPC(runCtx, cache, grpcAddr) })
+	}()
+
+	wg.Wait()
+	return errors.Join(errs...)
+}